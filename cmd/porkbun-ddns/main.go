@@ -2,20 +2,44 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
 	porkbunddns "github.com/jb0n/porkbun-ddns"
+	"github.com/jb0n/porkbun-ddns/providers/gandi"
+	"github.com/jb0n/porkbun-ddns/providers/porkbun"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "ns":
+			runNameserverCommand(os.Args[2:])
+			return
+		case "glue":
+			runGlueCommand(os.Args[2:])
+			return
+		}
+	}
+	runDDNS()
+}
+
+func runDDNS() {
 	createFlag := flag.Bool("create", false, "Create configuration file interactively")
+	daemonFlag := flag.Bool("daemon", false, "Run continuously, updating on an interval instead of once")
+	verboseFlag := flag.Bool("verbose", false, "Log debug-level detail in addition to normal status output")
+	quietFlag := flag.Bool("quiet", false, "Only log warnings and errors")
+	logFormatFlag := flag.String("log-format", "text", `Log output format, "text" or "json"`)
 	flag.Parse()
 
 	config, err := loadOrCreateConfig(*createFlag)
@@ -23,11 +47,66 @@ func main() {
 		log.Fatalf("Error loading configuration. err=%v", err)
 	}
 
-	if err := porkbunddns.UpdateDDNS(*config); err != nil {
+	logger, err := newLogger(*verboseFlag, *quietFlag, *logFormatFlag)
+	if err != nil {
+		log.Fatalf("Error configuring logger. err=%v", err)
+	}
+	config.Logger = logger
+
+	provider, err := newProvider(*config)
+	if err != nil {
+		log.Fatalf("Error configuring provider. err=%v", err)
+	}
+
+	if *daemonFlag {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := porkbunddns.UpdateDDNSLoop(ctx, *config, provider); err != nil {
+			log.Fatalf("Error running DDNS loop. err=%v", err)
+		}
+		return
+	}
+
+	if err := porkbunddns.UpdateDDNS(*config, provider); err != nil {
 		log.Fatalf("Error updating DDNS. err=%v", err)
 	}
 }
 
+func newProvider(config porkbunddns.Config) (porkbunddns.Provider, error) {
+	switch config.Provider {
+	case "", "porkbun":
+		return newPorkbunClient(config), nil
+	case "gandi":
+		return gandi.New(config.GandiAPIToken), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", config.Provider)
+	}
+}
+
+func newPorkbunClient(config porkbunddns.Config) *porkbun.Client {
+	return porkbun.New(config.PorkbunAPIKey, config.PorkbunAPISecret)
+}
+
+func newLogger(verbose, quiet bool, format string) (porkbunddns.Logger, error) {
+	level := slog.LevelInfo
+	switch {
+	case verbose:
+		level = slog.LevelDebug
+	case quiet:
+		level = slog.LevelWarn
+	}
+
+	switch format {
+	case "text":
+		return porkbunddns.NewTextLogger(os.Stdout, level), nil
+	case "json":
+		return porkbunddns.NewJSONLogger(os.Stdout, level), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q, must be \"text\" or \"json\"", format)
+	}
+}
+
 func loadOrCreateConfig(createMode bool) (*porkbunddns.Config, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -88,15 +167,48 @@ func saveConfig(path string, config *porkbunddns.Config) error {
 func promptForConfig() *porkbunddns.Config {
 	reader := bufio.NewReader(os.Stdin)
 	config := &porkbunddns.Config{
-		APIKey:    promptString(reader, "Porkbun API Key", ""),
-		APISecret: promptString(reader, "Porkbun API Secret", ""),
-		Domain:    promptString(reader, "Domain", "example.com"),
-		TTL:       promptInt(reader, "TTL (seconds)", "600"),
+		Provider: promptProvider(reader),
+		Domain:   promptString(reader, "Domain", "example.com"),
+	}
+
+	switch config.Provider {
+	case "gandi":
+		config.GandiAPIToken = promptString(reader, "Gandi API Token", "")
+	default:
+		config.PorkbunAPIKey = promptString(reader, "Porkbun API Key", "")
+		config.PorkbunAPISecret = promptString(reader, "Porkbun API Secret", "")
 	}
-	config.Subdomains = promptSubdomains(reader, config.Domain)
+
+	ttl := promptInt(reader, "TTL (seconds)", "600")
+	config.Records = promptRecords(reader, config.Domain, ttl)
 	return config
 }
 
+// promptRecords builds the DDNS A/AAAA RecordSpecs for the subdomains the
+// user wants kept in sync with their public IP. Records for other types
+// (MX, TXT, etc) can be added afterwards by editing the config file.
+func promptRecords(reader *bufio.Reader, domain string, ttl int) []porkbunddns.RecordSpec {
+	subdomains := promptSubdomains(reader, domain)
+
+	var records []porkbunddns.RecordSpec
+	for _, subdomain := range subdomains {
+		records = append(records,
+			porkbunddns.RecordSpec{Subdomain: subdomain, Type: "A", Content: porkbunddns.SentinelIPv4, TTL: ttl},
+			porkbunddns.RecordSpec{Subdomain: subdomain, Type: "AAAA", Content: porkbunddns.SentinelIPv6, TTL: ttl},
+		)
+	}
+	return records
+}
+
+func promptProvider(reader *bufio.Reader) string {
+	provider := strings.ToLower(promptString(reader, "DNS provider (porkbun/gandi)", "porkbun"))
+	if provider != "porkbun" && provider != "gandi" {
+		fmt.Printf("Unknown provider %q, must be \"porkbun\" or \"gandi\"\n", provider)
+		return promptProvider(reader)
+	}
+	return provider
+}
+
 func promptString(reader *bufio.Reader, prompt, defaultValue string) string {
 	if defaultValue != "" {
 		fmt.Printf("%s [%s]: ", prompt, defaultValue)
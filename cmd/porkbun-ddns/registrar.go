@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jb0n/porkbun-ddns/providers/porkbun"
+)
+
+func loadPorkbunClient() (*porkbun.Client, string) {
+	config, err := loadOrCreateConfig(false)
+	if err != nil {
+		log.Fatalf("Error loading configuration. err=%v", err)
+	}
+	if config.Provider != "" && config.Provider != "porkbun" {
+		log.Fatalf("Registrar operations are only supported with the porkbun provider, configuration uses %q", config.Provider)
+	}
+	return newPorkbunClient(*config), config.Domain
+}
+
+// runNameserverCommand implements the "ns" subcommand: get or set the
+// authoritative nameservers on a domain's registration.
+func runNameserverCommand(args []string) {
+	if len(args) == 0 {
+		log.Fatalf("Usage: porkbun-ddns ns <get|set> [nameservers...]")
+	}
+
+	client, domain := loadPorkbunClient()
+
+	switch args[0] {
+	case "get":
+		ns, err := client.GetNameservers(domain)
+		if err != nil {
+			log.Fatalf("Error getting nameservers. err=%v", err)
+		}
+		fmt.Println(strings.Join(ns, "\n"))
+	case "set":
+		if len(args[1:]) == 0 {
+			log.Fatalf("Usage: porkbun-ddns ns set <nameserver> [nameserver...]")
+		}
+		if err := client.UpdateNameservers(domain, args[1:]); err != nil {
+			log.Fatalf("Error updating nameservers. err=%v", err)
+		}
+		fmt.Println("Nameservers updated")
+	default:
+		log.Fatalf("Unknown ns subcommand %q, must be \"get\" or \"set\"", args[0])
+	}
+}
+
+// runGlueCommand implements the "glue" subcommand: manage glue (host)
+// records on a domain's registration.
+func runGlueCommand(args []string) {
+	if len(args) == 0 {
+		log.Fatalf("Usage: porkbun-ddns glue <get|create|update|delete> ...")
+	}
+
+	client, domain := loadPorkbunClient()
+
+	switch args[0] {
+	case "get":
+		hosts, err := client.GetGlueRecords(domain)
+		if err != nil {
+			log.Fatalf("Error getting glue records. err=%v", err)
+		}
+		for _, h := range hosts {
+			fmt.Printf("%s: %s\n", h.Host, strings.Join(h.IPs, ", "))
+		}
+	case "create", "update":
+		fs := flag.NewFlagSet("glue "+args[0], flag.ExitOnError)
+		fs.Parse(args[1:])
+		if fs.NArg() < 2 {
+			log.Fatalf("Usage: porkbun-ddns glue %s <subdomain> <ip> [ip...]", args[0])
+		}
+		subdomain := fs.Arg(0)
+		ips := fs.Args()[1:]
+
+		var err error
+		if args[0] == "create" {
+			err = client.CreateGlueRecord(domain, subdomain, ips)
+		} else {
+			err = client.UpdateGlueRecord(domain, subdomain, ips)
+		}
+		if err != nil {
+			log.Fatalf("Error %sing glue record. err=%v", args[0], err)
+		}
+		fmt.Println("Glue record saved")
+	case "delete":
+		if len(args[1:]) != 1 {
+			log.Fatalf("Usage: porkbun-ddns glue delete <subdomain>")
+		}
+		if err := client.DeleteGlueRecord(domain, args[1]); err != nil {
+			log.Fatalf("Error deleting glue record. err=%v", err)
+		}
+		fmt.Println("Glue record deleted")
+	default:
+		log.Fatalf("Unknown glue subcommand %q, must be one of \"get\", \"create\", \"update\", \"delete\"", args[0])
+	}
+}
@@ -0,0 +1,215 @@
+package porkbunddns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultIPQuorum is the fraction of resolvers that an address's agreeing
+// reports must exceed before it is treated as authoritative.
+const defaultIPQuorum = 0.5
+
+// IPResolver reports the caller's current public IP address as seen by a
+// single source (an HTTP echo endpoint, a DNS resolver, etc).
+type IPResolver interface {
+	Name() string
+	Resolve() (string, error)
+}
+
+// httpIPResolver resolves the current IP by fetching a URL that echoes the
+// caller's public address back as a plain-text body. network pins the
+// connection to "tcp4" or "tcp6" so a dual-stack host doesn't silently
+// answer for the other address family and get counted into the wrong
+// consensus vote.
+type httpIPResolver struct {
+	name    string
+	url     string
+	network string
+}
+
+func (r httpIPResolver) Name() string { return r.name }
+
+func (r httpIPResolver) Resolve() (string, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+				d := net.Dialer{Timeout: 5 * time.Second}
+				return d.DialContext(ctx, r.network, addr)
+			},
+		},
+	}
+
+	resp, err := client.Get(r.url)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s. err=%w", r.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s. err=%w", r.name, err)
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// dnsIPResolver resolves the current IP by asking a specific nameserver for
+// a record that it answers based on the resolving client's address, e.g.
+// "dig +short myip.opendns.com @resolver1.opendns.com".
+type dnsIPResolver struct {
+	name       string
+	nameserver string
+	query      string
+	txt        bool
+}
+
+func (r dnsIPResolver) Name() string { return r.name }
+
+func (r dnsIPResolver) Resolve() (string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 5 * time.Second}
+			return d.DialContext(ctx, network, r.nameserver)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if r.txt {
+		records, err := resolver.LookupTXT(ctx, r.query)
+		if err != nil {
+			return "", fmt.Errorf("failed to query %s. err=%w", r.name, err)
+		}
+		if len(records) == 0 {
+			return "", fmt.Errorf("no TXT records returned by %s", r.name)
+		}
+		return strings.Trim(records[0], `"`), nil
+	}
+
+	addrs, err := resolver.LookupHost(ctx, r.query)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s. err=%w", r.name, err)
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("no addresses returned by %s", r.name)
+	}
+	return addrs[0], nil
+}
+
+func defaultIPv4Resolvers() []IPResolver {
+	return []IPResolver{
+		httpIPResolver{name: "ipify", url: "https://api.ipify.org", network: "tcp4"},
+		httpIPResolver{name: "icanhazip", url: "https://ipv4.icanhazip.com", network: "tcp4"},
+		httpIPResolver{name: "ifconfig.co", url: "https://ifconfig.co/ip", network: "tcp4"},
+		dnsIPResolver{name: "opendns", nameserver: "resolver1.opendns.com:53", query: "myip.opendns.com"},
+		dnsIPResolver{name: "cloudflare", nameserver: "1.1.1.1:53", query: "whoami.cloudflare", txt: true},
+	}
+}
+
+func defaultIPv6Resolvers() []IPResolver {
+	return []IPResolver{
+		httpIPResolver{name: "ipify", url: "https://api64.ipify.org", network: "tcp6"},
+		httpIPResolver{name: "icanhazip", url: "https://ipv6.icanhazip.com", network: "tcp6"},
+		httpIPResolver{name: "ifconfig.co", url: "https://ifconfig.co/ip", network: "tcp6"},
+		dnsIPResolver{name: "cloudflare", nameserver: "[2606:4700:4700::1111]:53", query: "whoami.cloudflare", txt: true},
+	}
+}
+
+// IPResolverSpec configures one IPResolver from Config. Type selects the
+// resolver kind: "http" fetches URL and treats the plain-text body as the
+// address; "dns" queries Nameserver for Query, reading the answer as a TXT
+// record when TXT is set or an A/AAAA lookup otherwise.
+type IPResolverSpec struct {
+	Name       string
+	Type       string
+	URL        string
+	Nameserver string
+	Query      string
+	TXT        bool
+}
+
+// buildResolvers converts specs into IPResolvers, pinning any "http"
+// resolver to network ("tcp4" or "tcp6") the same way the built-in
+// defaults are pinned.
+func buildResolvers(specs []IPResolverSpec, network string) []IPResolver {
+	resolvers := make([]IPResolver, 0, len(specs))
+	for _, s := range specs {
+		switch s.Type {
+		case "dns":
+			resolvers = append(resolvers, dnsIPResolver{name: s.Name, nameserver: s.Nameserver, query: s.Query, txt: s.TXT})
+		default:
+			resolvers = append(resolvers, httpIPResolver{name: s.Name, url: s.URL, network: network})
+		}
+	}
+	return resolvers
+}
+
+// IPDetector queries a set of resolvers in parallel and returns the address
+// only when more than Quorum of them agree, defending against any single
+// resolver returning stale or wrong data.
+type IPDetector struct {
+	Resolvers []IPResolver
+	Quorum    float64
+}
+
+// Detect queries all resolvers in parallel and returns the address reported
+// by more than Quorum of them, or an error if no address reaches consensus.
+func (d IPDetector) Detect() (string, error) {
+	if len(d.Resolvers) == 0 {
+		return "", fmt.Errorf("no IP resolvers configured")
+	}
+
+	quorum := d.Quorum
+	if quorum <= 0 {
+		quorum = defaultIPQuorum
+	}
+
+	type result struct {
+		ip  string
+		err error
+	}
+
+	results := make([]result, len(d.Resolvers))
+	var wg sync.WaitGroup
+	for i, r := range d.Resolvers {
+		wg.Add(1)
+		go func(i int, r IPResolver) {
+			defer wg.Done()
+			ip, err := r.Resolve()
+			results[i] = result{ip: ip, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, res := range results {
+		if res.err != nil || res.ip == "" {
+			continue
+		}
+		counts[res.ip]++
+	}
+
+	var bestIP string
+	bestCount := 0
+	for ip, count := range counts {
+		if count > bestCount {
+			bestIP = ip
+			bestCount = count
+		}
+	}
+
+	if float64(bestCount) <= float64(len(d.Resolvers))*quorum {
+		return "", fmt.Errorf("failed to reach IP consensus: no address was reported by more than %.0f%% of %d resolvers", quorum*100, len(d.Resolvers))
+	}
+
+	return bestIP, nil
+}
@@ -0,0 +1,75 @@
+package porkbunddns
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeResolver struct {
+	name string
+	ip   string
+	err  error
+}
+
+func (r fakeResolver) Name() string             { return r.name }
+func (r fakeResolver) Resolve() (string, error) { return r.ip, r.err }
+
+func TestIPDetectorDetectMajority(t *testing.T) {
+	d := IPDetector{
+		Resolvers: []IPResolver{
+			fakeResolver{name: "a", ip: "203.0.113.1"},
+			fakeResolver{name: "b", ip: "203.0.113.1"},
+			fakeResolver{name: "c", ip: "203.0.113.2"},
+		},
+		Quorum: 0.5,
+	}
+
+	ip, err := d.Detect()
+	if err != nil {
+		t.Fatalf("Detect() returned err=%v", err)
+	}
+	if ip != "203.0.113.1" {
+		t.Fatalf("Detect() = %q, want %q", ip, "203.0.113.1")
+	}
+}
+
+func TestIPDetectorDetectNoQuorum(t *testing.T) {
+	d := IPDetector{
+		Resolvers: []IPResolver{
+			fakeResolver{name: "a", ip: "203.0.113.1"},
+			fakeResolver{name: "b", ip: "203.0.113.2"},
+			fakeResolver{name: "c", ip: "203.0.113.3"},
+		},
+		Quorum: 0.5,
+	}
+
+	if _, err := d.Detect(); err == nil {
+		t.Fatal("Detect() expected an error when no address reaches quorum, got nil")
+	}
+}
+
+func TestIPDetectorDetectIgnoresErrors(t *testing.T) {
+	d := IPDetector{
+		Resolvers: []IPResolver{
+			fakeResolver{name: "a", ip: "203.0.113.1"},
+			fakeResolver{name: "b", ip: "203.0.113.1"},
+			fakeResolver{name: "c", err: errors.New("timeout")},
+		},
+		Quorum: 0.5,
+	}
+
+	ip, err := d.Detect()
+	if err != nil {
+		t.Fatalf("Detect() returned err=%v", err)
+	}
+	if ip != "203.0.113.1" {
+		t.Fatalf("Detect() = %q, want %q", ip, "203.0.113.1")
+	}
+}
+
+func TestIPDetectorDetectNoResolvers(t *testing.T) {
+	d := IPDetector{}
+	if _, err := d.Detect(); err == nil {
+		t.Fatal("Detect() expected an error with no resolvers configured, got nil")
+	}
+}
@@ -0,0 +1,64 @@
+package porkbunddns
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// Logger is the interface UpdateDDNS uses for status output, letting callers
+// route it through their own logging pipeline instead of stdout — useful
+// when embedding this package or running it under systemd/journald.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Printf(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// SlogLogger adapts a *slog.Logger to the Logger interface.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger wraps logger as a Logger.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+// NewTextLogger returns a Logger that writes human-readable text lines to w
+// at or above level.
+func NewTextLogger(w *os.File, level slog.Level) *SlogLogger {
+	return NewSlogLogger(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+// NewJSONLogger returns a Logger that writes one JSON object per line to w
+// at or above level.
+func NewJSONLogger(w *os.File, level slog.Level) *SlogLogger {
+	return NewSlogLogger(slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+func (l *SlogLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Printf(format string, args ...interface{}) {
+	l.logger.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+}
+
+var defaultLogger Logger = NewTextLogger(os.Stdout, slog.LevelInfo)
+
+func (c Config) logger() Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return defaultLogger
+}
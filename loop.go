@@ -0,0 +1,72 @@
+package porkbunddns
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultMaxBackoffSeconds             = 3600
+	defaultResetAfterConsecutiveFailures = 3
+)
+
+// UpdateDDNSLoop runs UpdateDDNS on a repeating interval until ctx is
+// canceled. Consecutive failures back off the interval exponentially up to
+// MaxBackoffSeconds; the backoff resets to the normal interval once
+// ResetAfterConsecutiveFailures updates in a row succeed.
+func UpdateDDNSLoop(ctx context.Context, config Config, provider Provider) error {
+	interval := time.Duration(config.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	maxBackoff := time.Duration(config.MaxBackoffSeconds) * time.Second
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoffSeconds * time.Second
+	}
+
+	resetAfter := config.ResetAfterConsecutiveFailures
+	if resetAfter <= 0 {
+		resetAfter = defaultResetAfterConsecutiveFailures
+	}
+
+	logger := config.logger()
+	consecutiveFailures := 0
+	consecutiveSuccesses := 0
+
+	for {
+		if err := UpdateDDNS(config, provider); err != nil {
+			logger.Errorf("Update failed: %v", err)
+			consecutiveFailures++
+			consecutiveSuccesses = 0
+		} else {
+			consecutiveSuccesses++
+			if consecutiveSuccesses >= resetAfter {
+				consecutiveFailures = 0
+			}
+		}
+
+		wait := backoffDuration(interval, maxBackoff, consecutiveFailures)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func backoffDuration(interval, maxBackoff time.Duration, consecutiveFailures int) time.Duration {
+	if consecutiveFailures == 0 {
+		return interval
+	}
+
+	backoff := interval
+	for i := 0; i < consecutiveFailures; i++ {
+		backoff *= 2
+		if backoff <= 0 || backoff > maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
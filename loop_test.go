@@ -0,0 +1,47 @@
+package porkbunddns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDuration(t *testing.T) {
+	interval := 60 * time.Second
+	maxBackoff := time.Hour
+
+	cases := []struct {
+		consecutiveFailures int
+		want                time.Duration
+	}{
+		{0, interval},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{5, 32 * time.Minute},
+		{6, maxBackoff},
+		{28, maxBackoff},
+		{63, maxBackoff},
+		{1000, maxBackoff},
+	}
+
+	for _, c := range cases {
+		got := backoffDuration(interval, maxBackoff, c.consecutiveFailures)
+		if got != c.want {
+			t.Errorf("backoffDuration(%v, %v, %d) = %v, want %v", interval, maxBackoff, c.consecutiveFailures, got, c.want)
+		}
+	}
+}
+
+func TestBackoffDurationNeverNegativeOrZero(t *testing.T) {
+	interval := 60 * time.Second
+	maxBackoff := time.Hour
+
+	for f := 0; f <= 200; f++ {
+		got := backoffDuration(interval, maxBackoff, f)
+		if got <= 0 {
+			t.Fatalf("backoffDuration returned non-positive duration %v at consecutiveFailures=%d", got, f)
+		}
+		if got > maxBackoff {
+			t.Fatalf("backoffDuration returned %v > maxBackoff %v at consecutiveFailures=%d", got, maxBackoff, f)
+		}
+	}
+}
@@ -1,34 +1,72 @@
 package porkbunddns
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"time"
 )
 
-type Config struct {
-	APIKey     string
-	APISecret  string
-	Domain     string
-	Subdomains []string
-	TTL        int
-	IPv4File   string
-	IPv6File   string
-}
+// Sentinel values for RecordSpec.Content that get substituted with the
+// detected public IP address.
+const (
+	SentinelIPv4 = "$IPV4"
+	SentinelIPv6 = "$IPV6"
+)
 
-type PorkbunRequest struct {
-	APIKey       string `json:"apikey"`
-	SecretAPIKey string `json:"secretapikey"`
-	Content      string `json:"content"`
-	TTL          int    `json:"ttl"`
+// defaultRecordTTL is used for any RecordSpec that doesn't set its own TTL.
+const defaultRecordTTL = 600
+
+// RecordSpec describes one DNS record UpdateDDNS should keep in sync.
+// Content is either a literal value or one of the SentinelIPv4/SentinelIPv6
+// placeholders, which get replaced with the detected public IP.
+type RecordSpec struct {
+	Subdomain string
+	Type      string
+	Content   string
+	TTL       int
+	Prio      string
 }
 
-type PorkbunResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message,omitempty"`
+type Config struct {
+	Provider string
+	Domain   string
+	Records  []RecordSpec
+	IPv4File string
+	IPv6File string
+
+	PorkbunAPIKey    string
+	PorkbunAPISecret string
+
+	GandiAPIToken string
+
+	// PollIntervalSeconds, if set, is the interval UpdateDDNSLoop waits
+	// between updates. MaxBackoffSeconds caps the exponential backoff
+	// applied after consecutive failures, and ResetAfterConsecutiveFailures
+	// is how many successful updates in a row are needed to reset it.
+	PollIntervalSeconds           int
+	MaxBackoffSeconds             int
+	ResetAfterConsecutiveFailures int
+
+	// IPQuorum is the fraction of IP resolvers (0-1) that an address must be
+	// reported by more than before it is trusted; it defaults to 0.5 (a
+	// strict majority).
+	IPQuorum float64
+
+	// IPv4Resolvers and IPv6Resolvers override the resolvers queried for
+	// public IP consensus; each defaults to the built-in resolver set
+	// (defaultIPv4Resolvers/defaultIPv6Resolvers) when empty.
+	IPv4Resolvers []IPResolverSpec
+	IPv6Resolvers []IPResolverSpec
+
+	// StateDir overrides where the last-pushed IPs are persisted; it
+	// defaults to ~/.local/state/porkbun-ddns. DriftCheckInterval is how
+	// many runs with an unchanged IP are allowed before UpdateDDNS
+	// re-queries the provider anyway, to catch out-of-band drift.
+	StateDir           string
+	DriftCheckInterval int
+
+	// Logger receives UpdateDDNS's status output. It defaults to a Logger
+	// that writes text lines to stdout.
+	Logger Logger `json:"-"`
 }
 
 type DNSRecord struct {
@@ -40,78 +78,32 @@ type DNSRecord struct {
 	Prio    string `json:"prio"`
 }
 
-type RetrieveResponse struct {
-	Status  string      `json:"status"`
-	Records []DNSRecord `json:"records"`
-}
-
-func getCurrentIPv4() (string, error) {
-	resp, err := http.Get("https://api.ipify.org")
-	if err != nil {
-		return "", fmt.Errorf("failed to get current IPv4. err=%w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body. err=%w", err)
-	}
-
-	return strings.TrimSpace(string(body)), nil
-}
-
-func getCurrentIPv6() (string, error) {
-	resp, err := http.Get("https://api64.ipify.org")
-	if err != nil {
-		return "", fmt.Errorf("failed to get current IPv6. err=%w", err)
+func getCurrentIPv4(config Config) (string, error) {
+	resolvers := defaultIPv4Resolvers()
+	if len(config.IPv4Resolvers) > 0 {
+		resolvers = buildResolvers(config.IPv4Resolvers, "tcp4")
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	detector := IPDetector{Resolvers: resolvers, Quorum: config.IPQuorum}
+	ip, err := detector.Detect()
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body. err=%w", err)
+		return "", fmt.Errorf("failed to get current IPv4. err=%w", err)
 	}
-
-	return strings.TrimSpace(string(body)), nil
+	return ip, nil
 }
 
-func retrieveDNSRecords(config Config) ([]DNSRecord, error) {
-	url := fmt.Sprintf("https://api.porkbun.com/api/json/v3/dns/retrieve/%s", config.Domain)
-
-	reqData := struct {
-		APIKey       string `json:"apikey"`
-		SecretAPIKey string `json:"secretapikey"`
-	}{
-		APIKey:       config.APIKey,
-		SecretAPIKey: config.APISecret,
-	}
-
-	jsonData, err := json.Marshal(reqData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request data. err=%w", err)
-	}
-
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request. err=%w", err)
+func getCurrentIPv6(config Config) (string, error) {
+	resolvers := defaultIPv6Resolvers()
+	if len(config.IPv6Resolvers) > 0 {
+		resolvers = buildResolvers(config.IPv6Resolvers, "tcp6")
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	detector := IPDetector{Resolvers: resolvers, Quorum: config.IPQuorum}
+	ip, err := detector.Detect()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response. err=%w", err)
-	}
-
-	var retrieveResp RetrieveResponse
-	if err := json.Unmarshal(body, &retrieveResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response. err=%w", err)
-	}
-
-	if retrieveResp.Status != "SUCCESS" {
-		return nil, fmt.Errorf("API error. body=%s", string(body))
+		return "", fmt.Errorf("failed to get current IPv6. err=%w", err)
 	}
-
-	return retrieveResp.Records, nil
+	return ip, nil
 }
 
 func findDNSRecord(records []DNSRecord, domain, subdomain, recordType string) *DNSRecord {
@@ -128,117 +120,134 @@ func findDNSRecord(records []DNSRecord, domain, subdomain, recordType string) *D
 	return nil
 }
 
-func updatePorkbunDNS(config Config, subdomain, ip, recordType string) error {
-	url := fmt.Sprintf("https://api.porkbun.com/api/json/v3/dns/editByNameType/%s/%s/%s",
-		config.Domain, recordType, subdomain)
-
-	reqData := PorkbunRequest{
-		APIKey:       config.APIKey,
-		SecretAPIKey: config.APISecret,
-		Content:      ip,
-		TTL:          config.TTL,
+// resolveContent substitutes IP sentinels in a RecordSpec's Content with the
+// detected addresses, leaving any other value untouched.
+func resolveContent(content, currentIPv4, currentIPv6 string) string {
+	switch content {
+	case SentinelIPv4:
+		return currentIPv4
+	case SentinelIPv6:
+		return currentIPv6
+	default:
+		return content
 	}
+}
 
-	jsonData, err := json.Marshal(reqData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request data. err=%w", err)
+func recordSpecsNeedIP(records []RecordSpec, sentinel string) bool {
+	for _, r := range records {
+		if r.Content == sentinel {
+			return true
+		}
 	}
+	return false
+}
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to send request. err=%w", err)
+// allRecordsIPDriven reports whether every record is a $IPV4/$IPV6
+// sentinel, i.e. the only thing that could have changed since the last run
+// is the detected public IP. Configs that mix in literal records (static
+// MX, TXT, CAA, ...) can't use the unchanged-IP shortcut, since nothing
+// about a literal record depends on IP drift.
+func allRecordsIPDriven(records []RecordSpec) bool {
+	for _, r := range records {
+		if r.Content != SentinelIPv4 && r.Content != SentinelIPv6 {
+			return false
+		}
 	}
-	defer resp.Body.Close()
+	return true
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response. err=%w", err)
-	}
+func UpdateDDNS(config Config, provider Provider) error {
+	logger := config.logger()
 
-	var porkbunResp PorkbunResponse
-	if err := json.Unmarshal(body, &porkbunResp); err != nil {
-		return fmt.Errorf("failed to parse response. err=%w", err)
+	if len(config.Records) == 0 {
+		return fmt.Errorf("no records configured")
 	}
 
-	if porkbunResp.Status != "SUCCESS" {
-		return fmt.Errorf("API error. body=%s", string(body))
-	}
+	// Only resolve the public IPs that are actually referenced by a record.
+	var currentIPv4, currentIPv6 string
+	var err error
+	needsIPv4 := recordSpecsNeedIP(config.Records, SentinelIPv4)
+	needsIPv6 := recordSpecsNeedIP(config.Records, SentinelIPv6)
 
-	return nil
-}
+	if needsIPv4 {
+		currentIPv4, err = getCurrentIPv4(config)
+		if err != nil {
+			return err
+		}
+	}
+	if needsIPv6 {
+		currentIPv6, err = getCurrentIPv6(config)
+		if err != nil {
+			return err
+		}
+	}
 
-func UpdateDDNS(config Config) error {
-	// Get current public IPs
-	currentIPv4, err := getCurrentIPv4()
+	state, err := loadState(config)
 	if err != nil {
 		return err
 	}
-	currentIPv6, err := getCurrentIPv6()
-	if err != nil {
-		return err
+
+	driftCheckInterval := config.DriftCheckInterval
+	if driftCheckInterval <= 0 {
+		driftCheckInterval = defaultDriftCheckInterval
+	}
+
+	if (needsIPv4 || needsIPv6) && allRecordsIPDriven(config.Records) && state.IPv4 == currentIPv4 && state.IPv6 == currentIPv6 && state.RunsSinceCheck < driftCheckInterval {
+		logger.Debugf("IPv4 (%s) and IPv6 (%s) unchanged since %s, skipping DNS lookup", currentIPv4, currentIPv6, state.UpdatedAt)
+		state.RunsSinceCheck++
+		return saveState(config, state)
 	}
 
-	// Retrieve existing DNS records from Porkbun API
-	records, err := retrieveDNSRecords(config)
+	// Retrieve existing DNS records from the provider
+	records, err := provider.RetrieveRecords(config.Domain)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve DNS records. err=%w", err)
 	}
 
-	// Check if any A or AAAA records need updating (only need to check one of each type)
-	firstSubdomain := config.Subdomains[0]
+	recordIDs := make(map[string]string)
 
-	ipv4NeedsUpdate := false
-	aRecord := findDNSRecord(records, config.Domain, firstSubdomain, "A")
-	if aRecord != nil {
-		if aRecord.Content != currentIPv4 {
-			fmt.Printf("Current IPv4 is %s, DNS has %s (update needed)\n", currentIPv4, aRecord.Content)
-			ipv4NeedsUpdate = true
+	for _, spec := range config.Records {
+		displayName := spec.Subdomain
+		if displayName == "" {
+			displayName = config.Domain
 		} else {
-			fmt.Printf("IPv4 already up to date: %s\n", currentIPv4)
+			displayName = spec.Subdomain + "." + config.Domain
+		}
+
+		content := resolveContent(spec.Content, currentIPv4, currentIPv6)
+
+		existing := findDNSRecord(records, config.Domain, spec.Subdomain, spec.Type)
+		if existing != nil {
+			recordIDs[spec.Subdomain+"/"+spec.Type] = existing.ID
 		}
-	} else {
-		fmt.Printf("A record not found (update needed)\n")
-		ipv4NeedsUpdate = true
-	}
 
-	ipv6NeedsUpdate := false
-	aaaaRecord := findDNSRecord(records, config.Domain, firstSubdomain, "AAAA")
-	if aaaaRecord != nil {
-		if aaaaRecord.Content != currentIPv6 {
-			fmt.Printf("Current IPv6 is %s, DNS has %s (update needed)\n", currentIPv6, aaaaRecord.Content)
-			ipv6NeedsUpdate = true
+		if existing != nil && existing.Content == content && existing.Prio == spec.Prio {
+			logger.Debugf("%s record already up to date: %s -> %s", spec.Type, displayName, content)
+			continue
+		}
+
+		if existing != nil {
+			logger.Printf("Current %s for %s is %s (prio %q), DNS has %s (prio %q) (update needed)", spec.Type, displayName, content, spec.Prio, existing.Content, existing.Prio)
 		} else {
-			fmt.Printf("IPv6 already up to date: %s\n", currentIPv6)
+			logger.Printf("%s record not found for %s (update needed)", spec.Type, displayName)
+		}
+
+		ttl := spec.TTL
+		if ttl <= 0 {
+			ttl = defaultRecordTTL
 		}
-	} else {
-		fmt.Printf("AAAA record not found (update needed)\n")
-		ipv6NeedsUpdate = true
-	}
-
-	// Update DNS records only if needed
-	if ipv4NeedsUpdate || ipv6NeedsUpdate {
-		for _, subdomain := range config.Subdomains {
-			displayName := subdomain
-			if displayName == "" {
-				displayName = config.Domain
-			} else {
-				displayName = subdomain + "." + config.Domain
-			}
-
-			if ipv4NeedsUpdate {
-				if err := updatePorkbunDNS(config, subdomain, currentIPv4, "A"); err != nil {
-					return fmt.Errorf("error updating IPv4 DDNS for %s. err=%w", displayName, err)
-				}
-				fmt.Printf("A record updated: %s -> %s\n", displayName, currentIPv4)
-			}
-
-			if ipv6NeedsUpdate {
-				if err := updatePorkbunDNS(config, subdomain, currentIPv6, "AAAA"); err != nil {
-					return fmt.Errorf("error updating IPv6 DDNS for %s. err=%w", displayName, err)
-				}
-				fmt.Printf("AAAA record updated: %s -> %s\n", displayName, currentIPv6)
-			}
+
+		if err := provider.UpsertRecord(config.Domain, spec.Subdomain, spec.Type, content, ttl, spec.Prio); err != nil {
+			return fmt.Errorf("error updating %s record for %s. err=%w", spec.Type, displayName, err)
 		}
+		logger.Printf("%s record updated: %s -> %s", spec.Type, displayName, content)
 	}
-	return nil
+
+	newState := &State{
+		IPv4:      currentIPv4,
+		IPv6:      currentIPv6,
+		UpdatedAt: time.Now().Format(time.RFC3339),
+		RecordIDs: recordIDs,
+	}
+	return saveState(config, newState)
 }
@@ -0,0 +1,132 @@
+package porkbunddns
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeProvider struct {
+	records      []DNSRecord
+	upsertCalled int
+}
+
+func (p *fakeProvider) RetrieveRecords(domain string) ([]DNSRecord, error) {
+	return p.records, nil
+}
+
+func (p *fakeProvider) UpsertRecord(domain, subdomain, recordType, content string, ttl int, prio string) error {
+	p.upsertCalled++
+	return nil
+}
+
+func (p *fakeProvider) DeleteRecord(domain, subdomain, recordType string) error {
+	return nil
+}
+
+func testConfig(t *testing.T, records []RecordSpec) Config {
+	t.Helper()
+	return Config{
+		Domain:   "example.com",
+		Records:  records,
+		StateDir: t.TempDir(),
+	}
+}
+
+func TestUpdateDDNSSkipsUpsertWhenContentAndPrioMatch(t *testing.T) {
+	config := testConfig(t, []RecordSpec{
+		{Subdomain: "mail", Type: "MX", Content: "mx.example.com", Prio: "10"},
+	})
+	provider := &fakeProvider{
+		records: []DNSRecord{
+			{Name: "mail.example.com", Type: "MX", Content: "mx.example.com", Prio: "10"},
+		},
+	}
+
+	if err := UpdateDDNS(config, provider); err != nil {
+		t.Fatalf("UpdateDDNS() returned err=%v", err)
+	}
+	if provider.upsertCalled != 0 {
+		t.Fatalf("UpsertRecord called %d times, want 0", provider.upsertCalled)
+	}
+}
+
+func TestUpdateDDNSUpsertsWhenOnlyPrioChanged(t *testing.T) {
+	config := testConfig(t, []RecordSpec{
+		{Subdomain: "mail", Type: "MX", Content: "mx.example.com", Prio: "20"},
+	})
+	provider := &fakeProvider{
+		records: []DNSRecord{
+			{Name: "mail.example.com", Type: "MX", Content: "mx.example.com", Prio: "10"},
+		},
+	}
+
+	if err := UpdateDDNS(config, provider); err != nil {
+		t.Fatalf("UpdateDDNS() returned err=%v", err)
+	}
+	if provider.upsertCalled != 1 {
+		t.Fatalf("UpsertRecord called %d times, want 1", provider.upsertCalled)
+	}
+}
+
+// ipEchoServer starts an httptest server that answers every request with ip,
+// standing in for a resolver like ipify/icanhazip in tests.
+func ipEchoServer(t *testing.T, ip string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ip))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpdateDDNSSkipsRoundTripWhenIPUnchanged(t *testing.T) {
+	server := ipEchoServer(t, "203.0.113.9")
+
+	config := testConfig(t, []RecordSpec{
+		{Subdomain: "home", Type: "A", Content: SentinelIPv4},
+	})
+	config.IPv4Resolvers = []IPResolverSpec{{Name: "test", Type: "http", URL: server.URL}}
+	provider := &fakeProvider{}
+
+	state := &State{IPv4: "203.0.113.9", UpdatedAt: "2026-01-01T00:00:00Z"}
+	if err := saveState(config, state); err != nil {
+		t.Fatalf("saveState() returned err=%v", err)
+	}
+
+	if err := UpdateDDNS(config, provider); err != nil {
+		t.Fatalf("UpdateDDNS() returned err=%v", err)
+	}
+
+	if provider.upsertCalled != 0 {
+		t.Fatalf("UpsertRecord called %d times, want 0 (should have skipped the provider round trip)", provider.upsertCalled)
+	}
+}
+
+func TestUpdateDDNSDoesNotSkipLiteralRecordWhenIPUnchanged(t *testing.T) {
+	server := ipEchoServer(t, "203.0.113.9")
+
+	config := testConfig(t, []RecordSpec{
+		{Subdomain: "home", Type: "A", Content: SentinelIPv4},
+		{Subdomain: "", Type: "TXT", Content: "v=spf1 -all"},
+	})
+	config.IPv4Resolvers = []IPResolverSpec{{Name: "test", Type: "http", URL: server.URL}}
+	provider := &fakeProvider{
+		records: []DNSRecord{
+			{Name: "home.example.com", Type: "A", Content: "203.0.113.9"},
+		},
+	}
+
+	state := &State{IPv4: "203.0.113.9", UpdatedAt: "2026-01-01T00:00:00Z"}
+	if err := saveState(config, state); err != nil {
+		t.Fatalf("saveState() returned err=%v", err)
+	}
+
+	if err := UpdateDDNS(config, provider); err != nil {
+		t.Fatalf("UpdateDDNS() returned err=%v", err)
+	}
+
+	if provider.upsertCalled == 0 {
+		t.Fatal("UpsertRecord was never called; the literal TXT record should have been synced even though the IP was unchanged")
+	}
+}
@@ -0,0 +1,18 @@
+package porkbunddns
+
+// Provider is implemented by DNS backends capable of managing the records
+// for a domain. Each supported backend lives in its own providers/<name>
+// subpackage and is wired up by the caller based on Config.Provider.
+type Provider interface {
+	// RetrieveRecords returns all DNS records currently configured for domain.
+	RetrieveRecords(domain string) ([]DNSRecord, error)
+
+	// UpsertRecord creates or updates the record identified by subdomain and
+	// recordType, setting its content, TTL and priority. prio is only
+	// meaningful for record types that use it (MX, SRV) and may be empty.
+	UpsertRecord(domain, subdomain, recordType, content string, ttl int, prio string) error
+
+	// DeleteRecord removes the record identified by subdomain and recordType,
+	// if it exists.
+	DeleteRecord(domain, subdomain, recordType string) error
+}
@@ -0,0 +1,177 @@
+// Package gandi implements the porkbunddns.Provider interface against
+// Gandi's LiveDNS API (https://api.gandi.net/docs/livedns/).
+package gandi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	porkbunddns "github.com/jb0n/porkbun-ddns"
+)
+
+const baseURL = "https://api.gandi.net/v5/livedns"
+
+// Client implements porkbunddns.Provider using the Gandi LiveDNS API.
+type Client struct {
+	APIToken string
+}
+
+// New returns a Client authenticated with the given Gandi personal access token.
+func New(apiToken string) *Client {
+	return &Client{APIToken: apiToken}
+}
+
+type liveDNSRecord struct {
+	RRSetName   string   `json:"rrset_name"`
+	RRSetType   string   `json:"rrset_type"`
+	RRSetTTL    int      `json:"rrset_ttl"`
+	RRSetValues []string `json:"rrset_values"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (c *Client) do(method, url string, body interface{}) ([]byte, int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal request data. err=%w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build request. err=%w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request. err=%w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response. err=%w", err)
+	}
+
+	return respBody, resp.StatusCode, nil
+}
+
+func (c *Client) RetrieveRecords(domain string) ([]porkbunddns.DNSRecord, error) {
+	url := fmt.Sprintf("%s/domains/%s/records", baseURL, domain)
+
+	body, status, err := c.do(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("API error. status=%d body=%s", status, string(body))
+	}
+
+	var liveDNSRecords []liveDNSRecord
+	if err := json.Unmarshal(body, &liveDNSRecords); err != nil {
+		return nil, fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	var records []porkbunddns.DNSRecord
+	for _, r := range liveDNSRecords {
+		name := r.RRSetName
+		if name == "@" {
+			name = domain
+		} else {
+			name = name + "." + domain
+		}
+		for _, value := range r.RRSetValues {
+			prio, content := splitPrio(r.RRSetType, value)
+			records = append(records, porkbunddns.DNSRecord{
+				Name:    name,
+				Type:    r.RRSetType,
+				Content: content,
+				TTL:     fmt.Sprintf("%d", r.RRSetTTL),
+				Prio:    prio,
+			})
+		}
+	}
+
+	return records, nil
+}
+
+// splitPrio splits a LiveDNS rrset value into its priority and the
+// remaining content, mirroring the "<priority> <content>" encoding
+// UpsertRecord writes for MX/SRV. Other record types have no priority and
+// are returned unchanged.
+func splitPrio(recordType, value string) (prio, content string) {
+	switch recordType {
+	case "MX", "SRV":
+		if p, c, found := strings.Cut(value, " "); found {
+			return p, c
+		}
+	}
+	return "", value
+}
+
+func (c *Client) UpsertRecord(domain, subdomain, recordType, content string, ttl int, prio string) error {
+	name := subdomain
+	if name == "" {
+		name = "@"
+	}
+	url := fmt.Sprintf("%s/domains/%s/records/%s/%s", baseURL, domain, name, recordType)
+
+	value := content
+	if prio != "" {
+		// LiveDNS has no separate priority field; MX/SRV values are
+		// "<priority> <target>".
+		value = prio + " " + content
+	}
+
+	reqData := liveDNSRecord{
+		RRSetTTL:    ttl,
+		RRSetValues: []string{value},
+	}
+
+	body, status, err := c.do(http.MethodPut, url, reqData)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return fmt.Errorf("API error. status=%d body=%s", status, formatError(body))
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteRecord(domain, subdomain, recordType string) error {
+	name := subdomain
+	if name == "" {
+		name = "@"
+	}
+	url := fmt.Sprintf("%s/domains/%s/records/%s/%s", baseURL, domain, name, recordType)
+
+	body, status, err := c.do(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNoContent {
+		return fmt.Errorf("API error. status=%d body=%s", status, formatError(body))
+	}
+
+	return nil
+}
+
+func formatError(body []byte) string {
+	var errResp errorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+		return errResp.Message
+	}
+	return string(body)
+}
@@ -0,0 +1,25 @@
+package gandi
+
+import "testing"
+
+func TestSplitPrio(t *testing.T) {
+	cases := []struct {
+		recordType string
+		value      string
+		wantPrio   string
+		wantValue  string
+	}{
+		{"MX", "10 mail.example.com", "10", "mail.example.com"},
+		{"SRV", "10 5 5060 sip.example.com", "10", "5 5060 sip.example.com"},
+		{"A", "203.0.113.1", "", "203.0.113.1"},
+		{"TXT", "v=spf1 -all", "", "v=spf1 -all"},
+		{"MX", "mail.example.com", "", "mail.example.com"},
+	}
+
+	for _, c := range cases {
+		prio, value := splitPrio(c.recordType, c.value)
+		if prio != c.wantPrio || value != c.wantValue {
+			t.Errorf("splitPrio(%q, %q) = (%q, %q), want (%q, %q)", c.recordType, c.value, prio, value, c.wantPrio, c.wantValue)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package porkbun implements the porkbunddns.Provider interface against the
+// Porkbun DNS API (https://porkbun.com/api/json/v3/documentation).
+package porkbun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	porkbunddns "github.com/jb0n/porkbun-ddns"
+)
+
+const baseURL = "https://api.porkbun.com/api/json/v3"
+
+// Client implements porkbunddns.Provider using the Porkbun DNS API.
+type Client struct {
+	APIKey    string
+	APISecret string
+}
+
+// New returns a Client authenticated with the given Porkbun API credentials.
+func New(apiKey, apiSecret string) *Client {
+	return &Client{APIKey: apiKey, APISecret: apiSecret}
+}
+
+type request struct {
+	APIKey       string `json:"apikey"`
+	SecretAPIKey string `json:"secretapikey"`
+	Content      string `json:"content,omitempty"`
+	TTL          int    `json:"ttl,omitempty"`
+	Prio         string `json:"prio,omitempty"`
+}
+
+type response struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type retrieveResponse struct {
+	Status  string                  `json:"status"`
+	Records []porkbunddns.DNSRecord `json:"records"`
+}
+
+func (c *Client) post(url string, reqData interface{}) ([]byte, error) {
+	jsonData, err := json.Marshal(reqData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request data. err=%w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request. err=%w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response. err=%w", err)
+	}
+
+	return body, nil
+}
+
+func (c *Client) RetrieveRecords(domain string) ([]porkbunddns.DNSRecord, error) {
+	url := fmt.Sprintf("%s/dns/retrieve/%s", baseURL, domain)
+
+	body, err := c.post(url, request{APIKey: c.APIKey, SecretAPIKey: c.APISecret})
+	if err != nil {
+		return nil, err
+	}
+
+	var retrieveResp retrieveResponse
+	if err := json.Unmarshal(body, &retrieveResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if retrieveResp.Status != "SUCCESS" {
+		return nil, fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return retrieveResp.Records, nil
+}
+
+func (c *Client) UpsertRecord(domain, subdomain, recordType, content string, ttl int, prio string) error {
+	url := fmt.Sprintf("%s/dns/editByNameType/%s/%s/%s", baseURL, domain, recordType, subdomain)
+
+	reqData := request{
+		APIKey:       c.APIKey,
+		SecretAPIKey: c.APISecret,
+		Content:      content,
+		TTL:          ttl,
+		Prio:         prio,
+	}
+
+	body, err := c.post(url, reqData)
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if resp.Status != "SUCCESS" {
+		return fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteRecord(domain, subdomain, recordType string) error {
+	url := fmt.Sprintf("%s/dns/deleteByNameType/%s/%s/%s", baseURL, domain, recordType, subdomain)
+
+	body, err := c.post(url, request{APIKey: c.APIKey, SecretAPIKey: c.APISecret})
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if resp.Status != "SUCCESS" {
+		return fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package porkbun
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GlueRecord is a glue record (host record) associated with a domain, as
+// returned by Porkbun's /domain/getGlue endpoint.
+type GlueRecord struct {
+	Host string   `json:"host"`
+	IPs  []string `json:"ips"`
+}
+
+type nsRequest struct {
+	APIKey       string   `json:"apikey"`
+	SecretAPIKey string   `json:"secretapikey"`
+	NS           []string `json:"ns,omitempty"`
+}
+
+type getNsResponse struct {
+	Status string   `json:"status"`
+	NS     []string `json:"ns"`
+}
+
+type glueRequest struct {
+	APIKey       string   `json:"apikey"`
+	SecretAPIKey string   `json:"secretapikey"`
+	IPs          []string `json:"ips,omitempty"`
+}
+
+type getGlueResponse struct {
+	Status string       `json:"status"`
+	Hosts  []GlueRecord `json:"hosts"`
+}
+
+// GetNameservers returns the authoritative nameservers currently set on
+// domain's registration.
+func (c *Client) GetNameservers(domain string) ([]string, error) {
+	url := fmt.Sprintf("%s/domain/getNs/%s", baseURL, domain)
+
+	body, err := c.post(url, nsRequest{APIKey: c.APIKey, SecretAPIKey: c.APISecret})
+	if err != nil {
+		return nil, err
+	}
+
+	var nsResp getNsResponse
+	if err := json.Unmarshal(body, &nsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if nsResp.Status != "SUCCESS" {
+		return nil, fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nsResp.NS, nil
+}
+
+// UpdateNameservers replaces domain's authoritative nameservers with ns.
+func (c *Client) UpdateNameservers(domain string, ns []string) error {
+	url := fmt.Sprintf("%s/domain/updateNs/%s", baseURL, domain)
+
+	reqData := nsRequest{APIKey: c.APIKey, SecretAPIKey: c.APISecret, NS: ns}
+
+	body, err := c.post(url, reqData)
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if resp.Status != "SUCCESS" {
+		return fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nil
+}
+
+// GetGlueRecords returns the glue records registered for domain.
+func (c *Client) GetGlueRecords(domain string) ([]GlueRecord, error) {
+	url := fmt.Sprintf("%s/domain/getGlue/%s", baseURL, domain)
+
+	body, err := c.post(url, request{APIKey: c.APIKey, SecretAPIKey: c.APISecret})
+	if err != nil {
+		return nil, err
+	}
+
+	var glueResp getGlueResponse
+	if err := json.Unmarshal(body, &glueResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if glueResp.Status != "SUCCESS" {
+		return nil, fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return glueResp.Hosts, nil
+}
+
+// CreateGlueRecord creates a glue record for subdomain (e.g. "ns1") pointing
+// at ips.
+func (c *Client) CreateGlueRecord(domain, subdomain string, ips []string) error {
+	return c.glueRequest("createGlue", domain, subdomain, ips)
+}
+
+// UpdateGlueRecord replaces the IPs for an existing glue record.
+func (c *Client) UpdateGlueRecord(domain, subdomain string, ips []string) error {
+	return c.glueRequest("updateGlue", domain, subdomain, ips)
+}
+
+// DeleteGlueRecord removes the glue record for subdomain.
+func (c *Client) DeleteGlueRecord(domain, subdomain string) error {
+	url := fmt.Sprintf("%s/domain/deleteGlue/%s/%s", baseURL, domain, subdomain)
+
+	body, err := c.post(url, request{APIKey: c.APIKey, SecretAPIKey: c.APISecret})
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if resp.Status != "SUCCESS" {
+		return fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nil
+}
+
+func (c *Client) glueRequest(action, domain, subdomain string, ips []string) error {
+	url := fmt.Sprintf("%s/domain/%s/%s/%s", baseURL, action, domain, subdomain)
+
+	reqData := glueRequest{APIKey: c.APIKey, SecretAPIKey: c.APISecret, IPs: ips}
+
+	body, err := c.post(url, reqData)
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse response. err=%w", err)
+	}
+
+	if resp.Status != "SUCCESS" {
+		return fmt.Errorf("API error. body=%s", string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package porkbunddns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultDriftCheckInterval is how many consecutive skipped runs are
+// allowed before UpdateDDNS re-queries the provider even if the detected IP
+// hasn't changed, to catch out-of-band drift.
+const defaultDriftCheckInterval = 24
+
+// State is the last-known-good result of an UpdateDDNS run, persisted to
+// disk so subsequent runs can skip the provider round-trip when nothing has
+// changed.
+type State struct {
+	IPv4           string            `json:"ipv4"`
+	IPv6           string            `json:"ipv6"`
+	UpdatedAt      string            `json:"updated_at"`
+	RecordIDs      map[string]string `json:"record_ids,omitempty"`
+	RunsSinceCheck int               `json:"runs_since_check"`
+}
+
+func statePath(config Config) (string, error) {
+	dir := config.StateDir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory. err=%w", err)
+		}
+		dir = filepath.Join(home, ".local", "state", "porkbun-ddns")
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+func loadState(config Config) (*State, error) {
+	path, err := statePath(config)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, fmt.Errorf("failed to read state file. err=%w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file. err=%w", err)
+	}
+	return &state, nil
+}
+
+func saveState(config Config, state *State) error {
+	path, err := statePath(config)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory. err=%w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state. err=%w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write state file. err=%w", err)
+	}
+	return nil
+}